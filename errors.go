@@ -0,0 +1,251 @@
+package uri
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError describes an error found while parsing a URI, and where it was found.
+//
+// It wraps the validation error, so errors.Is(err, ErrInvalidHost) holds for a
+// ParseError with this Kind.
+type ParseError struct {
+	// Kind is the validation error, e.g. ErrInvalidHost. It may itself wrap a validation error.
+	Kind error
+
+	// Component is the name of the offending component, e.g. "host",
+	// or empty when the error is not specific to a component (e.g. ErrURITooLong).
+	Component string
+
+	// Offset is the byte index in Input of the first offending character.
+	// When no character is at fault (e.g. "https://-example.com"), it is the
+	// index of the offending component. It is -1 when undetermined.
+	Offset int
+
+	// Input is the parsed string, after any change made by options (e.g. WithStripLeadingMarks).
+	Input string
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Offset < 0:
+		return e.Kind.Error()
+	case e.Component == "":
+		return fmt.Sprintf("%v (at offset %d)", e.Kind, e.Offset)
+	default:
+		return fmt.Sprintf("%v (%s at offset %d)", e.Kind, e.Component, e.Offset)
+	}
+}
+
+// Unwrap returns the validation error.
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+// escapingError reports an invalid percent-encoded sequence in a component.
+//
+// It matches both ErrInvalidEscaping and the error for the component, e.g. ErrInvalidHost.
+type escapingError struct {
+	kind error
+}
+
+func (e escapingError) Error() string {
+	return fmt.Sprintf("%v: %v", e.kind, ErrInvalidEscaping)
+}
+
+func (e escapingError) Unwrap() error {
+	return e.kind
+}
+
+func (e escapingError) Is(target error) bool {
+	return target == ErrInvalidEscaping
+}
+
+// componentErrors maps validation errors to the component they report
+var componentErrors = []struct {
+	err       error
+	component ComponentContext
+}{
+	{ErrInvalidScheme, SchemeContext},
+	{ErrSchemeNotAllowed, SchemeContext},
+	{ErrInvalidUserInfo, UserInfoContext},
+	{ErrInvalidHost, HostContext},
+	{ErrMissingHost, HostContext},
+	{ErrInvalidPort, PortContext},
+	{ErrInvalidPath, PathContext},
+	{ErrInvalidQuery, QueryContext},
+	{ErrInvalidFragment, FragmentContext},
+}
+
+// newParseError locates the error returned when parsing raw, given the URI
+// which could be parsed, if any.
+func newParseError(err error, raw string, parsed URI, o *options) *ParseError {
+	e := &ParseError{Kind: err, Offset: -1, Input: raw}
+
+	u, ok := parsed.(*uri)
+	if !ok || u == nil {
+		return e
+	}
+
+	switch {
+	case errors.Is(err, ErrNonASCII):
+		e.Offset = strings.IndexFunc(raw, func(r rune) bool { return r >= utf8.RuneSelf })
+
+		return e
+	case errors.Is(err, ErrInvalidEscaping):
+		for _, component := range allComponents {
+			value, start := u.componentAt(raw, component)
+			if offset := invalidEscapingOffset(value); offset >= 0 && start >= 0 {
+				e.Component = component.String()
+				e.Offset = start + offset
+
+				return e
+			}
+		}
+
+		return e
+	}
+
+	for _, candidate := range componentErrors {
+		if !errors.Is(err, candidate.err) {
+			continue
+		}
+
+		value, start := u.componentAt(raw, candidate.component)
+		e.Component = candidate.component.String()
+		if start < 0 {
+			return e
+		}
+
+		offset := componentCharOffset(value, candidate.component, u.scheme, o)
+		if offset < 0 {
+			offset = labelOffset(value, err)
+		}
+		if offset < 0 && o.strictASCII {
+			offset = strings.IndexFunc(value, func(r rune) bool { return r >= utf8.RuneSelf })
+		}
+		if offset < 0 {
+			offset = 0
+		}
+		e.Offset = start + offset
+
+		return e
+	}
+
+	return e
+}
+
+// componentAt returns the raw value of a component and its index in the parsed string, or -1
+func (u *uri) componentAt(raw string, component ComponentContext) (string, int) {
+	hierStart := 0
+	if u.scheme != "" {
+		hierStart = len(u.scheme) + len(colonMark)
+	}
+
+	var value string
+	start := -1
+
+	switch component {
+	case SchemeContext:
+		value, start = u.scheme, 0
+	case QueryContext:
+		value, start = u.query, hierStart+len(u.hierPart)+len(questionMark)
+	case FragmentContext:
+		value, start = u.fragment, len(raw)-len(u.fragment)
+	default:
+		a := u.authority
+		if a == nil {
+			return "", -1
+		}
+
+		userInfoStart := hierStart + len(a.prefix)
+		hostStart := userInfoStart
+		if a.userinfo != "" || a.hasUserInfo {
+			hostStart += len(a.userinfo) + len(atBytes)
+		}
+		hostEnd := hostStart + len(a.host)
+		if strings.Contains(a.host, colonMark) {
+			// brackets around an IPv6 address
+			hostStart++
+			hostEnd += 2
+		}
+		portStart, pathStart := hostEnd, hostEnd
+		if hostEnd < len(raw) && raw[hostEnd] == ':' {
+			portStart = hostEnd + len(colonMark)
+			pathStart = portStart + len(a.port)
+		}
+
+		switch component {
+		case UserInfoContext:
+			value, start = a.userinfo, userInfoStart
+		case HostContext:
+			value, start = a.host, hostStart
+		case PortContext:
+			value, start = a.port, portStart
+		case PathContext:
+			value, start = a.path, pathStart
+		}
+	}
+
+	if start < 0 || start+len(value) > len(raw) || raw[start:start+len(value)] != value {
+		return value, -1
+	}
+
+	return value, start
+}
+
+// componentCharOffset returns the index of the first character which is not allowed in a component, or -1
+func componentCharOffset(value string, component ComponentContext, scheme string, o *options) int {
+	switch component {
+	case SchemeContext:
+		if r, _ := utf8.DecodeRuneInString(value); value != "" && !unicode.IsLetter(r) {
+			return 0
+		}
+
+		return invalidCharOffset(value, schemeChars, false)
+	case UserInfoContext:
+		return invalidCharOffset(value, userInfoChars, true)
+	case HostContext:
+		switch {
+		case strings.Contains(value, colonMark):
+			return invalidCharOffset(value, regNameChars+colonMark, true)
+		case o.isDNSScheme(scheme):
+			return invalidCharOffset(value, dnsHostChars, true)
+		default:
+			return invalidCharOffset(value, regNameChars, true)
+		}
+	case PortContext:
+		return strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	case PathContext:
+		return invalidCharOffset(value, segmentChars+"/", true)
+	case QueryContext:
+		return invalidCharOffset(value, o.queryChars(), true)
+	default:
+		return invalidCharOffset(value, queryChars, true)
+	}
+}
+
+// labelOffset returns the index of an invalid label reported in a DNS host name, or -1
+func labelOffset(host string, err error) int {
+	switch {
+	case errors.Is(err, ErrNumericTopLabel):
+		return strings.LastIndexByte(host, '.') + 1
+	case errors.Is(err, ErrEmptyFirstLabel):
+		return 0
+	case errors.Is(err, ErrEmptyInteriorLabel):
+		return strings.Index(host, "..") + 1
+	case errors.Is(err, ErrEmptyTrailingLabel):
+		return len(host) - 1
+	default:
+		return -1
+	}
+}
+
+func isHex(c byte) bool {
+	_, ok := fromHex(c)
+
+	return ok
+}
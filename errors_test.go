@@ -0,0 +1,75 @@
+package uri
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseError(t *testing.T) {
+	var tests = []struct {
+		raw       string
+		kind      error
+		component string
+		offset    int
+	}{
+		{"https://example.com/a/b{c}", ErrInvalidPath, "path", 23},
+		{"https://example.com/a/café/b c", ErrInvalidPath, "path", 29},
+		{"https://user@example.com:8080/a/%zz", ErrInvalidPath, "path", 32},
+		{"https://example.com/a%ED%A0%80", ErrInvalidEscaping, "path", 21},
+		{"https://example.com/a?q=%ED%BF%BF", ErrInvalidEscaping, "query", 24},
+		{"https://example.com/a?q=1&x={}", ErrInvalidQuery, "query", 28},
+		{"https://example.com/a#frag^ment", ErrInvalidFragment, "fragment", 26},
+		{"https://example.com:80a/", ErrInvalidPort, "port", 22},
+		{"https://us^er@example.com/", ErrInvalidUserInfo, "userinfo", 10},
+		{"https://bad_host.example.com/", ErrInvalidHost, "host", 11},
+		{"https://256.256.256.256/", ErrInvalidHost, "host", 8},
+		{"https://example.256/", ErrNumericTopLabel, "host", 16},
+		{"https://-example.com/", ErrInvalidHost, "host", 8},
+		{"https://www..example.com/", ErrEmptyInteriorLabel, "host", 12},
+		{"https://[fe80::1%25en0/a", ErrInvalidURI, "", -1},
+		{"1http://example.com", ErrInvalidScheme, "scheme", 0},
+		{"ht^tp://example.com", ErrInvalidScheme, "scheme", 2},
+		{"ht,tp://example.com", ErrInvalidScheme, "scheme", 2},
+		{"http:///a", ErrMissingHost, "host", 7},
+		{"http://example.com/é", ErrNonASCII, "", 19},
+	}
+
+	for _, test := range tests {
+		var opts []Option
+		if test.kind == ErrNonASCII {
+			opts = append(opts, WithASCIIOnly(true))
+		}
+
+		_, err := Parse(test.raw, opts...)
+		if !assert.Errorf(t, err, "expected %q to be invalid", test.raw) {
+			continue
+		}
+		assert.Truef(t, errors.Is(err, test.kind), "unexpected error for %q: %v", test.raw, err)
+
+		var parseErr *ParseError
+		if !assert.Truef(t, errors.As(err, &parseErr), "expected a *ParseError for %q", test.raw) {
+			continue
+		}
+		assert.Equal(t, test.raw, parseErr.Input)
+		assert.Equalf(t, test.component, parseErr.Component, "unexpected component for %q", test.raw)
+		assert.Equalf(t, test.offset, parseErr.Offset, "unexpected offset for %q", test.raw)
+	}
+}
+
+func Test_ParseErrorString(t *testing.T) {
+	_, err := Parse("https://example.com/a/b{c}")
+	assert.EqualError(t, err, "invalid path in URI (path at offset 23)")
+
+	_, err = Parse("https://example.com/a", WithMaxLength(10))
+	assert.EqualError(t, err, "URI exceeds the maximum length (at offset 10)")
+
+	_, err = Parse("//example.com")
+	assert.EqualError(t, err, "no scheme found in URI")
+
+	// validation errors reported by Validate() are not located
+	u, _ := Parse("https://example.com/a")
+	err = u.Builder().SetPath("/b{c}").URI().Validate()
+	assert.Equal(t, ErrInvalidPath, err)
+}
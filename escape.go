@@ -0,0 +1,184 @@
+package uri
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EscapePath percent-encodes all characters which are not allowed in a path,
+// e.g. "/a%20b/caf%C3%A9" for "/a b/café".
+//
+// Path separators ("/") are retained: use EscapePathSegment to escape a single segment.
+func EscapePath(path string) string {
+	return escapeWith(path, func(c byte) bool {
+		return isPathChar(c) || c == '/'
+	})
+}
+
+// EscapePathSegment percent-encodes all characters which are not allowed in a path segment,
+// including "/", e.g. "a%2Fb" for "a/b".
+func EscapePathSegment(segment string) string {
+	return escapePathSegment(segment)
+}
+
+// EscapeQueryComponent percent-encodes all characters which are not allowed in the key
+// or the value of a query parameter, e.g. "a%26b%3Dc%2Fd" for "a&b=c/d".
+//
+// Parameter delimiters ("&", "=") and "+" are escaped, as well as "/" and "?",
+// which are allowed in a query but not retained in a query component.
+func EscapeQueryComponent(component string) string {
+	return escapeWith(component, func(c byte) bool {
+		if c == '&' || c == '=' || c == '+' {
+			return false
+		}
+
+		return isUnreserved(c) || isSubDelim(c) || c == ':' || c == '@'
+	})
+}
+
+// EscapeFragment percent-encodes all characters which are not allowed in a fragment,
+// e.g. "section%201" for "section 1".
+func EscapeFragment(fragment string) string {
+	return escapeWith(fragment, func(c byte) bool {
+		return isPathChar(c) || c == '/' || c == '?'
+	})
+}
+
+// EscapeUserInfo percent-encodes all characters which are not allowed in a userinfo,
+// e.g. "user:p%40ss" for "user:p@ss".
+//
+// The ":" separating the user from the password is retained: a user name containing
+// a ":" must be escaped separately.
+func EscapeUserInfo(userinfo string) string {
+	return escapeWith(userinfo, func(c byte) bool {
+		return isUnreserved(c) || isSubDelim(c) || c == ':'
+	})
+}
+
+// EscapeMinimal percent-encodes only the characters which make a component invalid,
+// e.g. "/a%20b/caf%C3%A9" for "/a b/caf%C3%A9". Everything else is left byte-identical,
+// including valid escape sequences, and non-ASCII letters, which are valid in an IRI.
+//
+// Unlike EscapePath and the like, which escape a raw value, EscapeMinimal repairs a value
+// which is already escaped: a "%" is only escaped (as "%25") when it does not start a valid escape sequence.
+// It is idempotent, and a valid component is returned unchanged.
+//
+// Schemes and ports may not be escaped and are returned unchanged. Hosts are escaped as
+// registered names, with ":" retained for IPv6 literals.
+func EscapeMinimal(s string, ctx ComponentContext) string {
+	allowed := minimalEscapeAllowed(ctx)
+	if allowed == nil {
+		return s
+	}
+
+	var buf *bytes.Buffer
+	for i := 0; i < len(s); {
+		c := s[i]
+		size := 1
+
+		switch {
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			size = 3
+		case c < utf8.RuneSelf && c != '%' && allowed(c):
+		case c >= utf8.RuneSelf:
+			var r rune
+			r, size = utf8.DecodeRuneInString(s[i:])
+			if r != utf8.RuneError && unicode.IsLetter(r) {
+				break
+			}
+
+			fallthrough
+		default:
+			if buf == nil {
+				buf = bytes.NewBuffer(make([]byte, 0, len(s)+8))
+				buf.WriteString(s[:i])
+			}
+			buf.WriteString(escapeWith(s[i:i+size], func(byte) bool { return false }))
+			i += size
+
+			continue
+		}
+
+		if buf != nil {
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+
+	if buf == nil {
+		return s
+	}
+
+	return buf.String()
+}
+
+// minimalEscapeAllowed returns the ASCII characters allowed without escaping in a component,
+// or nil when the component may not be escaped
+func minimalEscapeAllowed(ctx ComponentContext) func(byte) bool {
+	switch ctx {
+	case UserInfoContext, HostContext:
+		return func(c byte) bool {
+			return isUnreserved(c) || isSubDelim(c) || c == ':'
+		}
+	case PathContext:
+		return func(c byte) bool {
+			return isPathChar(c) || c == '/'
+		}
+	case QueryContext, FragmentContext:
+		return func(c byte) bool {
+			return isPathChar(c) || c == '/' || c == '?'
+		}
+	default:
+		return nil
+	}
+}
+
+// UnescapePath decodes all percent-encoded octets in a path.
+//
+// It fails with ErrInvalidEscaping whenever an escape sequence is malformed (e.g. "%zz").
+func UnescapePath(path string) (string, error) {
+	return unescapeWith(url.PathUnescape, path)
+}
+
+// UnescapeQueryComponent decodes all percent-encoded octets in the key or the value
+// of a query parameter. A "+" is decoded as a space.
+//
+// It fails with ErrInvalidEscaping whenever an escape sequence is malformed (e.g. "%zz").
+func UnescapeQueryComponent(component string) (string, error) {
+	return unescapeWith(url.QueryUnescape, component)
+}
+
+// UnescapeFragment decodes all percent-encoded octets in a fragment.
+//
+// It fails with ErrInvalidEscaping whenever an escape sequence is malformed (e.g. "%zz").
+func UnescapeFragment(fragment string) (string, error) {
+	return unescapeWith(url.PathUnescape, fragment)
+}
+
+// UnescapeUserInfo decodes all percent-encoded octets in a userinfo.
+//
+// It fails with ErrInvalidEscaping whenever an escape sequence is malformed (e.g. "%zz").
+func UnescapeUserInfo(userinfo string) (string, error) {
+	return unescapeWith(url.PathUnescape, userinfo)
+}
+
+// isPathChar tells if a byte is allowed in a path segment without escaping (pchar in RFC 3986)
+func isPathChar(c byte) bool {
+	return isUnreserved(c) || isSubDelim(c) || c == ':' || c == '@'
+}
+
+func unescapeWith(unescape func(string) (string, error), component string) (string, error) {
+	if !strings.Contains(component, percentMark) && !strings.Contains(component, "+") {
+		return component, nil
+	}
+
+	unescaped, err := unescape(component)
+	if err != nil {
+		return "", ErrInvalidEscaping
+	}
+
+	return unescaped, nil
+}
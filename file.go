@@ -0,0 +1,128 @@
+package uri
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const localhost = "localhost"
+
+var errRelativeFilePath = fmt.Errorf("file path is not absolute: %w", ErrInvalidPath)
+
+// ToFilePath converts a "file" URI (RFC 8089) to a local file path, with its path percent-decoded,
+// e.g. "/etc/hosts" for "file:///etc/hosts".
+//
+// An empty host and "localhost" designate the local machine. Paths starting with a drive letter
+// are converted to Windows paths, e.g. `c:\dir\file` for "file:///c:/dir/file". Paths on
+// other hosts are converted to UNC paths, e.g. `\\server\share\file` for "file://server/share/file".
+//
+// It fails with ErrNotFileURI for any other scheme, and with an error wrapping ErrInvalidPath
+// whenever the path is not absolute or cannot be decoded.
+func (u *uri) ToFilePath() (string, error) {
+	if !strings.EqualFold(u.scheme, "file") {
+		return "", ErrNotFileURI
+	}
+
+	var host, path string
+	if a := u.authority; a != nil {
+		host, path = a.DecodedHost(), a.path
+	}
+
+	decoded, err := url.PathUnescape(path)
+	if err != nil || strings.IndexByte(decoded, 0) >= 0 {
+		return "", ErrInvalidPath
+	}
+
+	if !strings.HasPrefix(decoded, "/") {
+		return "", errRelativeFilePath
+	}
+
+	switch {
+	case host != "" && !strings.EqualFold(host, localhost):
+		return `\\` + host + strings.ReplaceAll(decoded, "/", `\`), nil
+	case isWindowsDrivePath(decoded[1:]):
+		return strings.ReplaceAll(decoded[1:], "/", `\`), nil
+	default:
+		return decoded, nil
+	}
+}
+
+// FromFilePath builds a "file" URI from an absolute local file path. It reverts URI.ToFilePath.
+//
+// Windows paths with a drive letter (e.g. `c:\dir\file`) and UNC paths (e.g. `\\server\share\file`)
+// are supported. Path segments are percent-encoded as needed, e.g. "/tmp/a b" yields "file:///tmp/a%20b".
+//
+// A relative path is invalid, with an error wrapping ErrInvalidPath.
+func FromFilePath(p string, opts ...Option) (URI, error) {
+	var host, path string
+
+	switch {
+	case strings.HasPrefix(p, `\\`):
+		unc := strings.ReplaceAll(p[2:], `\`, "/")
+		slash := strings.IndexByte(unc, '/')
+		if slash <= 0 {
+			return nil, ErrInvalidPath
+		}
+		host, path = unc[:slash], unc[slash:]
+	case isWindowsDrivePath(p):
+		path = "/" + strings.ReplaceAll(p, `\`, "/")
+	case strings.HasPrefix(p, "/"):
+		path = p
+	default:
+		return nil, errRelativeFilePath
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escapePathSegment(segment)
+	}
+
+	return Parse("file://"+host+strings.Join(segments, "/"), opts...)
+}
+
+// windowsFriendlyFileURI rewrites a "file" URI carrying a Windows path with a drive letter
+// or a UNC path, using "/" as a separator, e.g. "file:///C:/dir/file" for `file://C:\dir\file`
+// and "file://server/share" for `file://\\server\share`.
+//
+// Other URIs are returned unchanged. The query and fragment are never modified.
+func windowsFriendlyFileURI(raw string) string {
+	const scheme = "file:"
+	if len(raw) < len(scheme) || !strings.EqualFold(raw[:len(scheme)], scheme) {
+		return raw
+	}
+
+	path, rest := raw[len(scheme):], ""
+	if end := strings.IndexAny(path, "?#"); end >= 0 {
+		path, rest = path[:end], path[end:]
+	}
+
+	if !strings.Contains(path, `\`) {
+		return raw
+	}
+
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "//"), "/")
+	switch {
+	case strings.HasPrefix(trimmed, `\\`):
+		path = "//" + trimmed[2:]
+	case isWindowsDrivePath(trimmed):
+		path = "///" + trimmed
+	default:
+		return raw
+	}
+
+	return raw[:len(scheme)] + strings.ReplaceAll(path, `\`, "/") + rest
+}
+
+// isWindowsDrivePath tells if a path starts with a drive letter, e.g. "c:" or `c:\dir`
+func isWindowsDrivePath(p string) bool {
+	if len(p) < 2 || p[1] != ':' {
+		return false
+	}
+
+	if letter := p[0] | 0x20; letter < 'a' || letter > 'z' {
+		return false
+	}
+
+	return len(p) == 2 || p[2] == '/' || p[2] == '\\'
+}
@@ -0,0 +1,125 @@
+package uri
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToFilePath(t *testing.T) {
+	var tests = []struct {
+		raw, path string
+	}{
+		{"file:///etc/hosts", "/etc/hosts"},
+		{"file://localhost/etc/hosts", "/etc/hosts"},
+		{"FILE://LocalHost/etc/hosts", "/etc/hosts"},
+		{"file:/etc/hosts", "/etc/hosts"},
+		{"file:///tmp/a%20b/caf%C3%A9", "/tmp/a b/café"},
+		{"file:///c:/dir/file", `c:\dir\file`},
+		{"file:///C:/Program%20Files/app.exe", `C:\Program Files\app.exe`},
+		{"file:///c:", `c:`},
+		{"file:///cd:/dir", "/cd:/dir"},
+		{"file://server/share/file", `\\server\share\file`},
+	}
+
+	for _, test := range tests {
+		u, err := Parse(test.raw)
+		if !assert.NoErrorf(t, err, "expected %q to be valid", test.raw) {
+			continue
+		}
+
+		path, err := u.ToFilePath()
+		if assert.NoErrorf(t, err, "expected %q to convert to a file path", test.raw) {
+			assert.Equal(t, test.path, path)
+		}
+	}
+
+	u, _ := Parse("https://example.com/etc/hosts")
+	_, err := u.ToFilePath()
+	assert.Equal(t, ErrNotFileURI, err)
+
+	u, _ = Parse("file:etc/hosts")
+	_, err = u.ToFilePath()
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+
+	u, _ = Parse("file:///etc/a%00b")
+	_, err = u.ToFilePath()
+	assert.Equal(t, ErrInvalidPath, err)
+}
+
+func Test_FromFilePath(t *testing.T) {
+	var tests = []struct {
+		path, raw string
+	}{
+		{"/etc/hosts", "file:///etc/hosts"},
+		{"/tmp/a b/café", "file:///tmp/a%20b/caf%C3%A9"},
+		{"/tmp/a?b#c%d", "file:///tmp/a%3Fb%23c%25d"},
+		{`c:\dir\file`, "file:///c:/dir/file"},
+		{`C:\Program Files\app.exe`, "file:///C:/Program%20Files/app.exe"},
+		{`\\server\share\file`, "file://server/share/file"},
+		{"/", "file:///"},
+	}
+
+	for _, test := range tests {
+		u, err := FromFilePath(test.path)
+		if !assert.NoErrorf(t, err, "expected %q to convert to a file URI", test.path) {
+			continue
+		}
+		assert.Equal(t, test.raw, u.String())
+
+		// round-trip
+		path, err := u.ToFilePath()
+		if assert.NoError(t, err) {
+			assert.Equalf(t, test.path, path, "expected %q to round-trip", test.path)
+		}
+	}
+
+	for _, path := range []string{"etc/hosts", "", `dir\file`, `\\server`, "c:dir"} {
+		_, err := FromFilePath(path)
+		assert.Truef(t, errors.Is(err, ErrInvalidPath), "expected %q to be an invalid file path", path)
+	}
+}
+
+func Test_WindowsFriendly(t *testing.T) {
+	var tests = []struct {
+		raw, expected string
+	}{
+		{`file://C:\folder\file.txt`, "file:///C:/folder/file.txt"},
+		{`file:///C:\folder\file.txt`, "file:///C:/folder/file.txt"},
+		{`file:c:\folder\file.txt?q=a%5Cb#f`, "file:///c:/folder/file.txt?q=a%5Cb#f"},
+		{`file://\\server\share\file.txt`, "file://server/share/file.txt"},
+		{`FILE:\\server\share`, "FILE://server/share"},
+		{"file:///C:/folder/file.txt", "file:///C:/folder/file.txt"},
+	}
+
+	for _, test := range tests {
+		u, err := Parse(test.raw, WithWindowsFriendly(true))
+		if !assert.NoErrorf(t, err, "expected %q to be valid", test.raw) {
+			continue
+		}
+		assert.Equal(t, test.expected, u.String())
+	}
+
+	u, err := Parse(`file://\\server\share\file.txt`, WithWindowsFriendly(true))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "server", u.Authority().Host())
+		assert.Equal(t, "/share/file.txt", u.Authority().Path())
+
+		path, err := u.ToFilePath()
+		assert.NoError(t, err)
+		assert.Equal(t, `\\server\share\file.txt`, path)
+	}
+
+	// backslashes are only rewritten for file URIs
+	for _, raw := range []string{`file://C:\folder\file.txt`, `file://\\server\share`, `http://C:\folder\file.txt`, `file:///folder\file.txt`} {
+		_, err := Parse(raw)
+		assert.Errorf(t, err, "expected %q to be invalid by default", raw)
+	}
+
+	_, err = Parse(`http://\\server\share`, WithWindowsFriendly(true))
+	assert.Error(t, err)
+
+	_, err = Parse(`file:///folder\file.txt`, WithWindowsFriendly(true))
+	assert.Error(t, err)
+}
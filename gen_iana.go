@@ -0,0 +1,154 @@
+//go:build ignore
+
+// gen_iana generates iana.go from the registry of URI schemes published by IANA.
+//
+// Usage:
+//
+//	go run gen_iana.go [-src url_or_file] [-date YYYY-MM-DD]
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const registryURL = "https://www.iana.org/assignments/uri-schemes/uri-schemes-1.csv"
+
+func main() {
+	src := flag.String("src", registryURL, "URL or local path of the IANA uri-schemes CSV")
+	date := flag.String("date", time.Now().UTC().Format("2006-01-02"), "date of the snapshot")
+	output := flag.String("o", "iana.go", "output file")
+	flag.Parse()
+
+	records, err := readRegistry(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	schemes, err := registeredSchemes(records)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, `// Code generated by gen_iana.go. DO NOT EDIT.
+
+package uri
+
+//go:generate go run gen_iana.go
+
+// ianaSchemes is a snapshot of the permanent and provisional URI schemes registered at IANA.
+//
+// Historical schemes are not included.
+//
+// Source: %s, as of %s.
+//
+// See: https://www.iana.org/assignments/uri-schemes/uri-schemes.xhtml
+var ianaSchemes = map[string]bool{
+`, registryURL, *date)
+	for _, scheme := range schemes {
+		fmt.Fprintf(buf, "%q: true,\n", scheme)
+	}
+	buf.WriteString("}\n")
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, formatted, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readRegistry(src string) ([][]string, error) {
+	var r io.Reader
+
+	if strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://") {
+		resp, err := http.Get(src) //nolint:gosec,noctx // fixed registry URL, or provided by the developer
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching %s: %s", src, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	return reader.ReadAll()
+}
+
+// registeredSchemes returns the sorted permanent and provisional schemes of the registry.
+//
+// The CSV header is: URI Scheme,Template,Description,Well-Known URI Support,Status,Reference,Notes.
+func registeredSchemes(records [][]string) ([]string, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty registry")
+	}
+
+	schemeCol, statusCol := -1, -1
+	for i, col := range records[0] {
+		switch strings.TrimSpace(col) {
+		case "URI Scheme":
+			schemeCol = i
+		case "Status":
+			statusCol = i
+		}
+	}
+	if schemeCol < 0 || statusCol < 0 {
+		return nil, fmt.Errorf("unexpected registry header: %v", records[0])
+	}
+
+	seen := make(map[string]bool, len(records))
+	schemes := make([]string, 0, len(records))
+	for _, record := range records[1:] {
+		if len(record) <= schemeCol || len(record) <= statusCol {
+			continue
+		}
+
+		switch strings.TrimSpace(record[statusCol]) {
+		case "Permanent", "Provisional":
+		default:
+			continue
+		}
+
+		// some entries carry a note, e.g. "shttp (OBSOLETE)"
+		fields := strings.Fields(record[schemeCol])
+		if len(fields) == 0 {
+			continue
+		}
+		scheme := strings.ToLower(fields[0])
+		if seen[scheme] {
+			continue
+		}
+		seen[scheme] = true
+		schemes = append(schemes, scheme)
+	}
+
+	sort.Strings(schemes)
+
+	return schemes, nil
+}
@@ -1,7 +1,14 @@
 module github.com/fredbi/uri
 
+go 1.18
+
+require (
+	github.com/stretchr/testify v1.2.2
+	golang.org/x/net v0.35.0
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.2.2
+	golang.org/x/text v0.22.0 // indirect
 )
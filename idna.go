@@ -0,0 +1,33 @@
+package uri
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix is the ASCII compatible encoding prefix of internationalized domain name labels
+const acePrefix = "xn--"
+
+// Hostname returns the host as an internationalized name, with its
+// punycode-encoded labels decoded, e.g. "hàôé.com" for "xn--h-sfa1a6b.com".
+//
+// Host() still returns the host with its punycode-encoded labels.
+//
+// Labels are decoded with the IDNA lookup profile (UTS #46), which also maps and validates them.
+// IP literals, hosts without any punycode-encoded label and hosts rejected by IDNA are returned unchanged.
+func (a authorityInfo) Hostname() string {
+	if strings.Contains(a.host, colonMark) {
+		return a.Host()
+	}
+	if !strings.Contains(strings.ToLower(a.host), acePrefix) {
+		return a.host
+	}
+
+	hostname, err := idna.Lookup.ToUnicode(a.host)
+	if err != nil {
+		return a.host
+	}
+
+	return hostname
+}
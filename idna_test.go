@@ -0,0 +1,37 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Hostname(t *testing.T) {
+	var tests = []struct {
+		raw, host, hostname string
+	}{
+		{"https://xn--h-sfa1a6b.com/a", "xn--h-sfa1a6b.com", "hàôé.com"},
+		{"https://www.XN--bcher-kva.example/", "www.XN--bcher-kva.example", "www.bücher.example"},
+		{"https://xn--mnchen-3ya.de", "xn--mnchen-3ya.de", "münchen.de"},
+		{"foo://xn--r8jz45g/", "xn--r8jz45g", "例え"},
+		{"https://example.com/", "example.com", "example.com"},
+		{"https://[fe80::1]/", "fe80::1", "fe80::1"},
+		{"https://www.詹姆斯.org/", "www.詹姆斯.org", "www.詹姆斯.org"},
+		// invalid punycode is left unchanged
+		{"foo://xn--99999999999/", "xn--99999999999", "xn--99999999999"},
+		{"foo://xn--ls8h.xn--a_b/", "xn--ls8h.xn--a_b", "xn--ls8h.xn--a_b"},
+		// labels rejected by IDNA are left unchanged, e.g. with a misplaced zero width joiner or mixed directions
+		{"https://xn--b-dha379u.example/", "xn--b-dha379u.example", "xn--b-dha379u.example"},
+		{"https://xn--ab-wld.example/", "xn--ab-wld.example", "xn--ab-wld.example"},
+	}
+
+	for _, test := range tests {
+		u, err := Parse(test.raw)
+		if !assert.NoErrorf(t, err, "expected %q to be valid", test.raw) {
+			continue
+		}
+
+		assert.Equal(t, test.host, u.Authority().Host())
+		assert.Equalf(t, test.hostname, u.Authority().Hostname(), "unexpected hostname for %q", test.raw)
+	}
+}
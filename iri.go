@@ -0,0 +1,136 @@
+package uri
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// ToURI converts an IRI into a URI with ASCII characters only, as specified by RFC 3987 Section 3.1.
+//
+// Non-ASCII characters are percent-encoded as UTF-8, e.g. "/hélloô" becomes "/h%C3%A9llo%C3%B4".
+// For schemes using DNS host names, non-ASCII host labels are punycode-encoded instead,
+// e.g. "bücher.example" becomes "xn--bcher-kva.example".
+//
+// A URI with ASCII characters only is returned unchanged. The result is validated.
+func (u *uri) ToURI() (URI, error) {
+	converted := u.Clone().(*uri)
+	converted.query = escapeNonASCII(u.query)
+	converted.fragment = escapeNonASCII(u.fragment)
+
+	if a := converted.authority; a != nil {
+		a.userinfo = escapeNonASCII(a.userinfo)
+		a.path = escapeNonASCII(a.path)
+
+		if u.options().isDNSScheme(u.scheme) {
+			host, err := toASCIIHost(a.host)
+			if err != nil {
+				return converted, ErrInvalidHost
+			}
+			a.host = host
+		} else {
+			a.host = escapeNonASCII(a.host)
+		}
+
+		converted.hierPart = a.String()
+	}
+
+	return converted, converted.Validate()
+}
+
+// ToIRI converts a URI into an IRI, as specified by RFC 3987 Section 3.2.
+//
+// Percent-encoded octets which form printable non-ASCII UTF-8 characters are decoded,
+// e.g. "/h%C3%A9llo" becomes "/héllo". Other percent-encoded octets remain unchanged.
+// For schemes using DNS host names, punycode-encoded host labels are decoded,
+// e.g. "xn--bcher-kva.example" becomes "bücher.example".
+//
+// ToIRI reverts ToURI. The result is validated.
+func (u *uri) ToIRI() (URI, error) {
+	converted := u.Clone().(*uri)
+	converted.query = unescapeNonASCII(u.query)
+	converted.fragment = unescapeNonASCII(u.fragment)
+
+	if a := converted.authority; a != nil {
+		a.userinfo = unescapeNonASCII(a.userinfo)
+		a.path = unescapeNonASCII(a.path)
+
+		if u.options().isDNSScheme(u.scheme) {
+			a.host = a.Hostname()
+		} else {
+			a.host = unescapeNonASCII(a.host)
+		}
+
+		converted.hierPart = a.String()
+	}
+
+	return converted, converted.Validate()
+}
+
+// toASCIIHost punycode-encodes the non-ASCII labels of a host, with the IDNA lookup profile
+func toASCIIHost(host string) (string, error) {
+	if isASCII(host) {
+		return host, nil
+	}
+
+	return idna.Lookup.ToASCII(host)
+}
+
+// escapeNonASCII percent-encodes all non-ASCII bytes
+func escapeNonASCII(component string) string {
+	if isASCII(component) {
+		return component
+	}
+
+	return escapeWith(component, func(c byte) bool {
+		return c < utf8.RuneSelf
+	})
+}
+
+// unescapeNonASCII decodes percent-encoded octets forming printable non-ASCII UTF-8 characters
+func unescapeNonASCII(component string) string {
+	if !strings.Contains(component, percentMark) {
+		return component
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(component)))
+	for i := 0; i < len(component); i++ {
+		if component[i] != '%' {
+			buf.WriteByte(component[i])
+
+			continue
+		}
+
+		// decode a sequence of escaped octets, up to the length of a UTF-8 character
+		var octets [utf8.UTFMax]byte
+		n, end := 0, i
+		for n < utf8.UTFMax && end+2 < len(component) && component[end] == '%' {
+			c, ok := unhex(component[end+1], component[end+2])
+			if !ok || c < utf8.RuneSelf {
+				break
+			}
+			octets[n] = c
+			n++
+			end += 3
+
+			if utf8.FullRune(octets[:n]) {
+				break
+			}
+		}
+
+		r, size := utf8.DecodeRune(octets[:n])
+		if n == 0 || r == utf8.RuneError || size != n || !unicode.IsPrint(r) {
+			buf.WriteByte(component[i])
+
+			continue
+		}
+
+		buf.WriteRune(r)
+		i = end - 1
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,448 @@
+package uri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Option allows for fine-tuning the behavior of the URI parser and validator.
+type Option func(*options)
+
+type options struct {
+	requireHostForSchemes map[string]bool
+	asciiOnly             bool
+	forbidFragment        map[string]bool
+	redactedPassword      bool
+	dnsSchemes            map[string]bool
+	suffixInheritance     bool
+	validateOnly          map[ComponentContext]bool
+	safeRedirectSchemes   map[string]bool
+	ianaSchemesOnly       bool
+	maxUserInfoLength     int
+	stripLeadingMarks     bool
+	defaultPortFunc       func(scheme string) (int, bool)
+	strictIPv6            bool
+	strictASCII           bool
+	maxLength             int
+	windowsFriendly       bool
+	allowedSchemes        map[string]bool
+	disallowedSchemes     map[string]bool
+	strictQueryDelims     bool
+	allowTrailingDot      bool
+	allowLeadingZerosIPv4 bool
+	portRange             *[2]int
+	semicolonQuerySep     bool
+}
+
+// defaultOpts are the options used when none are provided.
+//
+// It must be considered read-only.
+var defaultOpts = &options{}
+
+// validate checks the options which may be set with invalid values.
+func (o *options) validate() error {
+	if r := o.portRange; r != nil && (r[0] < 0 || r[1] > maxPort || r[0] > r[1]) {
+		return fmt.Errorf("invalid port range [%d, %d]: %w", r[0], r[1], ErrInvalidPort)
+	}
+
+	return nil
+}
+
+func applyOptions(opts []Option) *options {
+	if len(opts) == 0 {
+		return defaultOpts
+	}
+
+	o := *defaultOpts
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	return &o
+}
+
+// WithRequireHostForSchemes declares the schemes for which an empty host
+// is invalid whenever an authority section is present (e.g. "http:///path").
+//
+// By default, all schemes listed in SchemesWithDNSHost require a host, except "file".
+// URIs such as "file:///path" are always valid unless "file" is explicitly listed.
+//
+// Calling this option without any scheme allows an empty host for all schemes.
+func WithRequireHostForSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.requireHostForSchemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.requireHostForSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithASCIIOnly rejects any non-ASCII character in any component of the URI,
+// with ErrNonASCII.
+//
+// Percent-encoded non-ASCII octets remain valid.
+//
+// By default, non-ASCII characters are accepted (IRI-friendly).
+func WithASCIIOnly(enabled bool) Option {
+	return func(o *options) {
+		o.asciiOnly = enabled
+	}
+}
+
+// WithStrictASCII rejects IRIs: any non-ASCII character makes the URI invalid, with the error
+// corresponding to the offending component (e.g. ErrInvalidHost for "http://www.詹姆斯.org/").
+//
+// This is similar to WithASCIIOnly, which reports ErrNonASCII for all components.
+// Percent-encoded non-ASCII octets remain valid.
+//
+// By default, non-ASCII characters are accepted (IRI-friendly).
+func WithStrictASCII(enabled bool) Option {
+	return func(o *options) {
+		o.strictASCII = enabled
+	}
+}
+
+// WithForbidFragmentForSchemes declares schemes for which a fragment is not allowed.
+//
+// A URI with such a scheme and a non-empty fragment is invalid, with ErrInvalidFragment
+// (e.g. "urn:isbn:12345#frag" with "urn").
+//
+// By default, fragments are allowed for all schemes.
+func WithForbidFragmentForSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.forbidFragment = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.forbidFragment[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithStrictQueryDelims rejects the unescaped gen-delims "/" and "?" in the query, with ErrInvalidQuery:
+// these must be percent-encoded as "%2F" and "%3F" (e.g. "?a=b%2Fc" instead of "?a=b/c").
+//
+// This suits strict API gateways, which forbid these characters in query values.
+//
+// By default, "/" and "?" are accepted in the query, as per RFC 3986.
+func WithStrictQueryDelims(enabled bool) Option {
+	return func(o *options) {
+		o.strictQueryDelims = enabled
+	}
+}
+
+// WithSemicolonQuerySeparator splits query parameters on ";" as well as on "&", as recommended
+// by HTML 4 and still used by some legacy applications, e.g. "a=1;b=2" has the parameters a=1 and b=2.
+//
+// This affects Query(), QueryPairs() and QueryRawPairs(), as well as the Builder methods
+// SetQueryParam, AddQueryParam and DelQueryParam.
+//
+// By default, parameters are only separated by "&", and parameters containing ";" are
+// skipped by Query(), like url.ParseQuery does.
+func WithSemicolonQuerySeparator(enabled bool) Option {
+	return func(o *options) {
+		o.semicolonQuerySep = enabled
+	}
+}
+
+// WithRedactedPassword replaces any password in the userinfo by "xxxxx"
+// whenever the URI is rendered as a string.
+//
+// The URI retains the actual password, e.g. as returned by Authority().Password().
+func WithRedactedPassword(enabled bool) Option {
+	return func(o *options) {
+		o.redactedPassword = enabled
+	}
+}
+
+// WithAllowTrailingDotInHost accepts a single trailing dot in DNS host names, which denotes
+// a fully-qualified domain name (e.g. "http://example.com./").
+//
+// Other empty labels remain invalid (e.g. "http://example..com./").
+//
+// By default, a trailing dot is rejected with ErrEmptyTrailingLabel.
+func WithAllowTrailingDotInHost(enabled bool) Option {
+	return func(o *options) {
+		o.allowTrailingDot = enabled
+	}
+}
+
+// WithDNSSchemes declares extra schemes for which the host must be a valid DNS
+// host name (RFC 1035), in addition to the schemes listed in SchemesWithDNSHost.
+//
+// Example: Parse("myscheme://bad_host", WithDNSSchemes("myscheme")) fails with ErrInvalidHost.
+func WithDNSSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.dnsSchemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.dnsSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithSchemeSuffixInheritance allows schemes of the form "base+transport"
+// (e.g. "git+ssh", "svn+https") to validate their host like their base scheme.
+//
+// With this option enabled, "git+ssh://host/repo" validates "host" as a DNS host name,
+// since "git" is listed in SchemesWithDNSHost (or declared with WithDNSSchemes).
+//
+// Only the part before the first "+" is considered. Schemes with a "unix" transport
+// (e.g. "http+unix") are exempted, since their host is usually an encoded socket path.
+//
+// A scheme explicitly declared as a DNS scheme remains so, regardless of this option.
+//
+// By default, the option is disabled.
+func WithSchemeSuffixInheritance(enabled bool) Option {
+	return func(o *options) {
+		o.suffixInheritance = enabled
+	}
+}
+
+// WithValidateOnly restricts validation to the provided components of the URI.
+//
+// Other components are still parsed and available from accessors, but their content
+// is not checked. This is useful e.g. when the query is going to be replaced anyway.
+//
+// Example: Parse("http://host/a?q=<invalid>", WithValidateOnly(HostContext, PathContext))
+//
+// Options which apply to the URI as a whole, such as WithASCIIOnly, are not affected.
+//
+// By default, all components are validated. Calling this option without any
+// component disables the validation of all components.
+func WithValidateOnly(components ...ComponentContext) Option {
+	return func(o *options) {
+		o.validateOnly = make(map[ComponentContext]bool, len(components))
+		for _, component := range components {
+			o.validateOnly[component] = true
+		}
+	}
+}
+
+// WithSafeRedirectSchemes declares the schemes considered safe to follow
+// on redirects, as reported by IsSafeRedirectScheme.
+//
+// By default, only "http" and "https" are safe. Calling this option without any
+// scheme considers no scheme safe.
+func WithSafeRedirectSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.safeRedirectSchemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.safeRedirectSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithIANASchemesOnly rejects any scheme which is not registered at IANA
+// (as permanent or provisional), with ErrSchemeNotAllowed.
+//
+// Registered schemes are checked against a snapshot of the IANA registry shipped
+// with this package. Schemes are compared case-insensitively.
+//
+// By default, any syntactically valid scheme is allowed.
+func WithIANASchemesOnly(enabled bool) Option {
+	return func(o *options) {
+		o.ianaSchemesOnly = enabled
+	}
+}
+
+// WithAllowedSchemes rejects any scheme which is not listed, with ErrSchemeNotAllowed,
+// e.g. to only accept "http" and "https" URIs and reject "javascript:alert(1)".
+//
+// Schemes are compared case-insensitively. Relative references, which have no scheme, are not affected.
+//
+// By default, or whenever called without any scheme, all schemes are allowed.
+func WithAllowedSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.allowedSchemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.allowedSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithDisallowedSchemes rejects the listed schemes, with ErrSchemeNotAllowed,
+// e.g. "javascript", "data" or "file". Other schemes remain allowed.
+//
+// Schemes are compared case-insensitively. A scheme which is both allowed with
+// WithAllowedSchemes and disallowed is rejected.
+func WithDisallowedSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.disallowedSchemes = make(map[string]bool, len(schemes))
+		for _, scheme := range schemes {
+			o.disallowedSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// WithMaxUserInfoLength limits the length of the userinfo (e.g. "user:password"), in bytes,
+// as it appears in the URI (i.e. escaped).
+//
+// A longer userinfo is invalid, with an error wrapping ErrInvalidUserInfo.
+//
+// By default, or whenever the length is not positive, the userinfo is not limited.
+func WithMaxUserInfoLength(n int) Option {
+	return func(o *options) {
+		o.maxUserInfoLength = n
+	}
+}
+
+// WithPortRange rejects any explicit port outside of the range [min, max] with an error wrapping
+// ErrInvalidPort, e.g. "http://host:8080" with WithPortRange(1, 1024).
+//
+// URIs without a port are not affected, regardless of the default port for their scheme.
+//
+// By default, the full range [0, 65535] is accepted. A range which is empty or not within
+// these bounds is invalid: parsing then fails with an error wrapping ErrInvalidPort.
+func WithPortRange(min, max int) Option {
+	return func(o *options) {
+		o.portRange = &[2]int{min, max}
+	}
+}
+
+// WithMaxLength rejects any URI longer than n bytes with ErrURITooLong, before parsing it.
+//
+// This guards services accepting untrusted input against pathological URIs.
+//
+// By default, or whenever the length is not positive, the length of URIs is not limited.
+func WithMaxLength(n int) Option {
+	return func(o *options) {
+		o.maxLength = n
+	}
+}
+
+// WithWindowsFriendly accepts "file" URIs carrying Windows paths with "\\" as a separator,
+// as often produced on Windows, and rewrites them with "/" before parsing:
+//   - paths with a drive letter, e.g. `file://C:\folder\file.txt` becomes "file:///C:/folder/file.txt"
+//   - UNC paths, e.g. `file://\\server\share\file.txt` becomes "file://server/share/file.txt"
+//
+// Backslashes are only rewritten in the path of "file" URIs.
+//
+// By default, backslashes are invalid in URIs.
+func WithWindowsFriendly(enabled bool) Option {
+	return func(o *options) {
+		o.windowsFriendly = enabled
+	}
+}
+
+// WithStripLeadingMarks removes any leading byte order mark (U+FEFF) or bidirectional
+// control character (e.g. U+200E) before parsing. Such characters are often found
+// in copy-pasted URIs.
+//
+// By default, URIs starting with such characters are invalid, with ErrLeadingMark.
+func WithStripLeadingMarks(enabled bool) Option {
+	return func(o *options) {
+		o.stripLeadingMarks = enabled
+	}
+}
+
+// WithDefaultPortFunc declares a function providing the default port for a scheme,
+// e.g. to support custom schemes.
+//
+// The function receives the scheme in lower case. Whenever it returns false,
+// the built-in default ports are used (e.g. 443 for "https").
+//
+// Default ports are used by URI.PortOrDefault() and to compare ports with SameResource.
+func WithDefaultPortFunc(fn func(scheme string) (int, bool)) Option {
+	return func(o *options) {
+		o.defaultPortFunc = fn
+	}
+}
+
+// WithAllowLeadingZerosIPv4 accepts IPv4 hosts with zero-padded octets, as emitted by some legacy systems
+// (e.g. "http://010.0.0.1/").
+//
+// Octets are always decimal: "010" stands for 10, not for the octal value 8.
+// Octets greater than 255 remain invalid.
+//
+// By default, such hosts are not IPv4 addresses, and are rejected for schemes using DNS host names.
+func WithAllowLeadingZerosIPv4(enabled bool) Option {
+	return func(o *options) {
+		o.allowLeadingZerosIPv4 = enabled
+	}
+}
+
+// WithStrictIPv6 only accepts IPv6 hosts in their canonical text form, as recommended by RFC 5952.
+//
+// With this option enabled, an IPv6 host is invalid (with ErrInvalidHost) whenever:
+//   - it is the unspecified address (e.g. "[::]")
+//   - hexadecimal digits are not in lower case (e.g. "[FE80::1]")
+//   - leading zeros are not suppressed (e.g. "[fe80::01]")
+//   - the longest run of zero fields is not compressed with "::", or a single zero
+//     field is compressed (e.g. "[fe80:0:0:0:0:0:0:1]", "[2001:db8::1:1:1:1:1]")
+//   - an embedded IPv4 address is not an IPv4-mapped or IPv4-translated address (e.g. "[::192.0.2.1]"),
+//     or such an address is not written with an embedded IPv4 address (e.g. "[::ffff:c000:201]")
+//
+// Zone identifiers are not affected. By default, any valid IPv6 address is accepted.
+func WithStrictIPv6(enabled bool) Option {
+	return func(o *options) {
+		o.strictIPv6 = enabled
+	}
+}
+
+func (o *options) defaultPort(scheme string) int {
+	if o.defaultPortFunc != nil {
+		if port, ok := o.defaultPortFunc(strings.ToLower(scheme)); ok {
+			return port
+		}
+	}
+
+	return defaultPortForScheme(scheme)
+}
+
+func (o *options) isSafeRedirectScheme(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+	if o.safeRedirectSchemes != nil {
+		return o.safeRedirectSchemes[scheme]
+	}
+
+	return scheme == "http" || scheme == "https"
+}
+
+func (o *options) allowsScheme(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+	if o.disallowedSchemes[scheme] {
+		return false
+	}
+
+	return len(o.allowedSchemes) == 0 || o.allowedSchemes[scheme]
+}
+
+// queryChars returns the characters allowed in a query, besides letters, ASCII digits and percent-encoded octets
+func (o *options) queryChars() string {
+	if o.strictQueryDelims {
+		return segmentChars
+	}
+
+	return queryChars
+}
+
+func (o *options) validates(component ComponentContext) bool {
+	return o.validateOnly == nil || o.validateOnly[component]
+}
+
+func (o *options) isDNSScheme(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+	if SchemesWithDNSHost[scheme] || o.dnsSchemes[scheme] {
+		return true
+	}
+
+	if !o.suffixInheritance {
+		return false
+	}
+
+	plus := strings.Index(scheme, "+")
+	if plus <= 0 || scheme[plus+1:] == "unix" {
+		return false
+	}
+
+	base := scheme[:plus]
+
+	return SchemesWithDNSHost[base] || o.dnsSchemes[base]
+}
+
+func (o *options) requiresHost(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+	if o.requireHostForSchemes != nil {
+		return o.requireHostForSchemes[scheme]
+	}
+
+	return SchemesWithDNSHost[scheme] && scheme != "file"
+}
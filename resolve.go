@@ -0,0 +1,154 @@
+package uri
+
+import "strings"
+
+// ResolveReference resolves a URI reference against this URI, used as the base URI,
+// as specified by RFC 3986 Section 5.2 (strict mode).
+//
+// The reference is typically obtained with ParseReference. A reference with a scheme
+// is considered absolute and only gets its dot-segments removed.
+//
+// Since this package does not distinguish an empty query from an undefined one,
+// a reference with an empty query (e.g. "g?") is resolved as one without a query.
+//
+// The resolved URI retains the options of the base URI.
+// A nil reference resolves to a copy of the base URI.
+func (u *uri) ResolveReference(ref URI) URI {
+	if isNilURI(ref) {
+		return u.Clone()
+	}
+
+	base, r := u.Components(), ref.Components()
+	var target Components
+
+	// Components don't tell an empty userinfo from an absent one: carry it from
+	// the URI that provides the authority of the target.
+	authoritySource := URI(u)
+	if r.Scheme != "" || r.HasAuthority {
+		authoritySource = ref
+	}
+	_, hasUserInfo := authoritySource.Authority().RawUserInfo()
+
+	switch {
+	case r.Scheme != "":
+		target = r
+		target.Path = removeDotSegments(r.Path)
+	case r.HasAuthority:
+		target = r
+		target.Scheme = base.Scheme
+		target.Path = removeDotSegments(r.Path)
+	default:
+		target = base
+		target.Fragment = r.Fragment
+
+		switch {
+		case r.Path == "":
+			if r.Query != "" {
+				target.Query = r.Query
+			}
+		case strings.HasPrefix(r.Path, "/"):
+			target.Path = removeDotSegments(r.Path)
+			target.Query = r.Query
+		default:
+			target.Path = removeDotSegments(mergePaths(base, r.Path))
+			target.Query = r.Query
+		}
+	}
+
+	authority := &authorityInfo{
+		userinfo:    target.UserInfo,
+		hasUserInfo: hasUserInfo,
+		host:        target.Host,
+		port:        target.Port,
+		path:        target.Path,
+	}
+	if target.HasAuthority {
+		authority.prefix = authorityPrefix
+	}
+
+	return &uri{
+		scheme:    target.Scheme,
+		hierPart:  authority.String(),
+		query:     target.Query,
+		fragment:  target.Fragment,
+		authority: authority,
+		opts:      u.opts,
+
+		parsedQuery: newQueryCache(target.Query),
+	}
+}
+
+func isNilURI(ref URI) bool {
+	if ref == nil {
+		return true
+	}
+	r, isURI := ref.(*uri)
+
+	return isURI && r == nil
+}
+
+// mergePaths merges a relative path reference with the path of the base URI,
+// as specified by RFC 3986 Section 5.2.3.
+func mergePaths(base Components, refPath string) string {
+	if base.HasAuthority && base.Path == "" {
+		return "/" + refPath
+	}
+
+	slash := strings.LastIndexByte(base.Path, '/')
+	if slash < 0 {
+		return refPath
+	}
+
+	return base.Path[:slash+1] + refPath
+}
+
+// removeDotSegments removes the special "." and ".." complete segments from a path,
+// as specified by RFC 3986 Section 5.2.4.
+func removeDotSegments(input string) string {
+	if !strings.Contains(input, ".") {
+		return input
+	}
+
+	output := make([]byte, 0, len(input))
+
+	for input != "" {
+		switch {
+		case strings.HasPrefix(input, "../"):
+			input = input[3:]
+		case strings.HasPrefix(input, "./"):
+			input = input[2:]
+		case strings.HasPrefix(input, "/./"):
+			input = input[2:]
+		case input == "/.":
+			input = "/"
+		case strings.HasPrefix(input, "/../"):
+			input = input[3:]
+			output = removeLastSegment(output)
+		case input == "/..":
+			input = "/"
+			output = removeLastSegment(output)
+		case input == "." || input == "..":
+			input = ""
+		default:
+			// move the first path segment, including its initial "/" if any, to the output
+			end := strings.IndexByte(input[1:], '/') + 1
+			if end <= 0 {
+				end = len(input)
+			}
+			output = append(output, input[:end]...)
+			input = input[end:]
+		}
+	}
+
+	return string(output)
+}
+
+func removeLastSegment(output []byte) []byte {
+	for i := len(output) - 1; i >= 0; i-- {
+		if output[i] == '/' {
+			return output[:i]
+		}
+	}
+
+	return output[:0]
+}
@@ -0,0 +1,158 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rfc3986Base = "http://a/b/c/d;p?q"
+
+// Test_ResolveReference exercises the examples from RFC 3986 Section 5.4
+func Test_ResolveReference(t *testing.T) {
+	var tests = []struct {
+		ref, expected string
+	}{
+		// normal examples (Section 5.4.1)
+		// NOTE: "g:h" is not exercised, since this package requires schemes with at least 2 characters
+		{"g", "http://a/b/c/g"},
+		{"./g", "http://a/b/c/g"},
+		{"g/", "http://a/b/c/g/"},
+		{"/g", "http://a/g"},
+		{"//g", "http://g"},
+		{"?y", "http://a/b/c/d;p?y"},
+		{"g?y", "http://a/b/c/g?y"},
+		{"#s", "http://a/b/c/d;p?q#s"},
+		{"g#s", "http://a/b/c/g#s"},
+		{"g?y#s", "http://a/b/c/g?y#s"},
+		{";x", "http://a/b/c/;x"},
+		{"g;x", "http://a/b/c/g;x"},
+		{"g;x?y#s", "http://a/b/c/g;x?y#s"},
+		{"", "http://a/b/c/d;p?q"},
+		{".", "http://a/b/c/"},
+		{"./", "http://a/b/c/"},
+		{"..", "http://a/b/"},
+		{"../", "http://a/b/"},
+		{"../g", "http://a/b/g"},
+		{"../..", "http://a/"},
+		{"../../", "http://a/"},
+		{"../../g", "http://a/g"},
+
+		// abnormal examples (Section 5.4.2)
+		{"../../../g", "http://a/g"},
+		{"../../../../g", "http://a/g"},
+		{"/./g", "http://a/g"},
+		{"/../g", "http://a/g"},
+		{"g.", "http://a/b/c/g."},
+		{".g", "http://a/b/c/.g"},
+		{"g..", "http://a/b/c/g.."},
+		{"..g", "http://a/b/c/..g"},
+		{"./../g", "http://a/b/g"},
+		{"./g/.", "http://a/b/c/g/"},
+		{"g/./h", "http://a/b/c/g/h"},
+		{"g/../h", "http://a/b/c/h"},
+		{"g;x=1/./y", "http://a/b/c/g;x=1/y"},
+		{"g;x=1/../y", "http://a/b/c/y"},
+		{"g?y/./x", "http://a/b/c/g?y/./x"},
+		{"g?y/../x", "http://a/b/c/g?y/../x"},
+		{"g#s/./x", "http://a/b/c/g#s/./x"},
+		{"g#s/../x", "http://a/b/c/g#s/../x"},
+		{"http:g", "http:g"},
+	}
+
+	base, err := Parse(rfc3986Base)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, test := range tests {
+		ref, err := ParseReference(test.ref)
+		if !assert.NoErrorf(t, err, "expected reference %q to be valid", test.ref) {
+			continue
+		}
+
+		resolved := base.ResolveReference(ref)
+		assert.Equalf(t, test.expected, resolved.String(), "unexpected resolution of %q", test.ref)
+		assert.NoErrorf(t, resolved.Validate(), "expected resolution of %q to be valid", test.ref)
+	}
+}
+
+func Test_ResolveReferenceEdge(t *testing.T) {
+	// base without a path
+	base, err := Parse("http://a?q")
+	assert.NoError(t, err)
+	ref, err := ParseReference("g")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://a/g", base.ResolveReference(ref).String())
+
+	// base without authority
+	base, err = Parse("urn:a:b/c")
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:a:b/g", base.ResolveReference(ref).String())
+
+	// reference with an authority and userinfo
+	base, err = Parse("https://user@a/b/c")
+	assert.NoError(t, err)
+	ref, err = ParseReference("//other@g:8080/x/../y?z")
+	assert.NoError(t, err)
+	resolved := base.ResolveReference(ref)
+	assert.Equal(t, "https://other@g:8080/y?z", resolved.String())
+	assert.Equal(t, "g", resolved.Authority().Host())
+	assert.Equal(t, "8080", resolved.Authority().Port())
+
+	// reference with a colon in its query
+	ref, err = ParseReference("g?y:z#s:t")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://user@a/b/g?y:z#s:t", base.ResolveReference(ref).String())
+
+	// reference with an IPv6 host
+	ref, err = ParseReference("//[fe80::1%25en0]/x")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://[fe80::1%25en0]/x", base.ResolveReference(ref).String())
+
+	// reference with an empty userinfo
+	ref, err = ParseReference("//@h/x")
+	assert.NoError(t, err)
+	resolved = base.ResolveReference(ref)
+	assert.Equal(t, "https://@h/x", resolved.String())
+	userinfo, hasUserInfo := resolved.Authority().RawUserInfo()
+	assert.Empty(t, userinfo)
+	assert.True(t, hasUserInfo)
+
+	// base with an empty userinfo
+	base, err = Parse("http://@a/b/c")
+	assert.NoError(t, err)
+	ref, err = ParseReference("g")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://@a/b/g", base.ResolveReference(ref).String())
+
+	// nil reference, as returned on some parsing errors
+	ref, err = ParseReference("g/too/long", WithMaxLength(4))
+	assert.Error(t, err)
+	assert.NotPanics(t, func() {
+		resolved = base.ResolveReference(ref)
+	})
+	assert.Equal(t, "http://@a/b/c", resolved.String())
+	assert.Equal(t, "http://@a/b/c", base.ResolveReference(nil).String())
+}
+
+func Test_removeDotSegments(t *testing.T) {
+	var tests = []struct {
+		path, expected string
+	}{
+		{"", ""},
+		{"/", "/"},
+		{"/a/b/c/./../../g", "/a/g"},
+		{"mid/content=5/../6", "mid/6"},
+		{"/a/b/..", "/a/"},
+		{"/..", "/"},
+		{"../a", "a"},
+		{"a/..", "/"},
+		{"/a//../b", "/a/b"},
+		{"/a.b/c..d/.e", "/a.b/c..d/.e"},
+	}
+
+	for _, test := range tests {
+		assert.Equalf(t, test.expected, removeDotSegments(test.path), "unexpected result for %q", test.path)
+	}
+}
@@ -0,0 +1,68 @@
+package uri
+
+import "strings"
+
+// Scheme is the scheme of a URI, with helpers to reason about it.
+//
+// All methods are case-insensitive, e.g. Scheme("HTTPS").IsSecure() is true.
+type Scheme string
+
+// secureSchemes knows about schemes for which the transport is secured (e.g. with TLS or SSH).
+var secureSchemes = map[string]bool{
+	"coaps":     true,
+	"coaps+tcp": true,
+	"coaps+ws":  true,
+	"ftps":      true,
+	"https":     true,
+	"imaps":     true,
+	"ircs":      true,
+	"ldaps":     true,
+	"msrps":     true,
+	"pop3s":     true,
+	"rediss":    true,
+	"rtsps":     true,
+	"sftp":      true,
+	"sips":      true,
+	"smtps":     true,
+	"ssh":       true,
+	"stuns":     true,
+	"turns":     true,
+	"wss":       true,
+}
+
+// String returns the scheme as a string.
+func (s Scheme) String() string {
+	return string(s)
+}
+
+// IsHTTP tells if the scheme is "http" or "https".
+func (s Scheme) IsHTTP() bool {
+	scheme := strings.ToLower(string(s))
+
+	return scheme == "http" || scheme == "https"
+}
+
+// IsSecure tells if the scheme designates a secured transport, e.g. "https", "wss" or "ftps".
+func (s Scheme) IsSecure() bool {
+	return secureSchemes[strings.ToLower(string(s))]
+}
+
+// UsesDNS tells if the host for this scheme must be a DNS host name (see SchemesWithDNSHost).
+func (s Scheme) UsesDNS() bool {
+	return SchemesWithDNSHost[strings.ToLower(string(s))]
+}
+
+// DefaultPort returns the default port for this scheme, if one is known.
+func (s Scheme) DefaultPort() (uint16, bool) {
+	port := defaultPortForScheme(string(s))
+	if port <= 0 || port > maxPort {
+		return 0, false
+	}
+
+	return uint16(port), true
+}
+
+// SchemeTyped returns the scheme of the URI as a Scheme.
+func (u *uri) SchemeTyped() Scheme {
+	return Scheme(u.scheme)
+}
@@ -0,0 +1,55 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scheme(t *testing.T) {
+	var tests = []struct {
+		scheme      Scheme
+		isHTTP      bool
+		isSecure    bool
+		usesDNS     bool
+		defaultPort uint16
+	}{
+		{"http", true, false, true, 80},
+		{"HTTPS", true, true, true, 443},
+		{"wss", false, true, true, 443},
+		{"ftps", false, true, false, 990},
+		{"ftp", false, false, true, 21},
+		{"coaps", false, true, true, 5684},
+		{"mailto", false, false, true, 0},
+		{"urn", false, false, false, 0},
+		{"", false, false, false, 0},
+	}
+
+	for _, test := range tests {
+		assert.Equalf(t, test.isHTTP, test.scheme.IsHTTP(), "unexpected IsHTTP() for %q", test.scheme)
+		assert.Equalf(t, test.isSecure, test.scheme.IsSecure(), "unexpected IsSecure() for %q", test.scheme)
+		assert.Equalf(t, test.usesDNS, test.scheme.UsesDNS(), "unexpected UsesDNS() for %q", test.scheme)
+
+		port, ok := test.scheme.DefaultPort()
+		assert.Equalf(t, test.defaultPort, port, "unexpected DefaultPort() for %q", test.scheme)
+		assert.Equalf(t, test.defaultPort != 0, ok, "unexpected DefaultPort() for %q", test.scheme)
+	}
+
+	u, err := Parse("HTTPS://example.com/a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Scheme("HTTPS"), u.SchemeTyped())
+		assert.Equal(t, "HTTPS", u.SchemeTyped().String())
+		assert.Equal(t, u.Scheme(), u.SchemeTyped().String())
+		assert.True(t, u.SchemeTyped().IsSecure())
+	}
+
+	// out of range ports are never truncated
+	defaultPortsMx.Lock()
+	defaultPorts["myscheme"] = 70000
+	defaultPortsMx.Unlock()
+	defer UnregisterDefaultPort("myscheme")
+
+	port, ok := Scheme("myscheme").DefaultPort()
+	assert.False(t, ok)
+	assert.Equal(t, uint16(0), port)
+}
@@ -0,0 +1,97 @@
+package uri
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// NullURI is a URI which may be null, to be used as a scan destination, like sql.NullString.
+//
+// Example:
+//
+//	var u uri.NullURI
+//	err := row.Scan(&u)
+//	if u.Valid {
+//		fmt.Println(u.URI.Host())
+//	}
+//
+// Values are parsed as URIs with the default options. URI is never nil after a successful
+// scan: a null value yields an empty URI, with Valid set to false.
+type NullURI struct {
+	URI   URI
+	Valid bool // Valid is true if URI is not null
+}
+
+// Scan a NullURI from a database value, which must be a string, a []byte or nil.
+//
+// This implements the sql.Scanner interface.
+func (n *NullURI) Scan(src interface{}) error {
+	u := &uri{}
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+
+	n.URI, n.Valid = u, src != nil
+
+	return nil
+}
+
+// Value returns the URI as a string, or nil when the NullURI is not valid.
+//
+// Like URI.Value, the password is never redacted.
+//
+// This implements the driver.Valuer interface.
+func (n NullURI) Value() (driver.Value, error) {
+	if !n.Valid || n.URI == nil {
+		return nil, nil
+	}
+
+	if valuer, ok := n.URI.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	return n.URI.String(), nil
+}
+
+// Scan a URI from a database value, which must be a string, a []byte or nil.
+//
+// The value is parsed as a URI with the options of the receiver (or the default options
+// for an empty URI), and an invalid value yields the parsing error. A nil value produces
+// an empty URI, which retains the options of the receiver.
+//
+// This implements the sql.Scanner interface. Use NullURI as a scan destination.
+func (u *uri) Scan(src interface{}) error {
+	var raw string
+
+	switch value := src.(type) {
+	case nil:
+		*u = uri{opts: u.opts}
+
+		return nil
+	case string:
+		raw = value
+	case []byte:
+		raw = string(value)
+	default:
+		return fmt.Errorf("cannot scan type %T into a URI: %w", src, ErrInvalidURI)
+	}
+
+	parsed, err := parse(raw, false, u.options())
+	if err != nil {
+		return err
+	}
+
+	*u = *(parsed.(*uri))
+
+	return nil
+}
+
+// Value returns the URI as a string, to be stored in a database.
+//
+// The password is never redacted, even when the URI is parsed with WithRedactedPassword:
+// the stored value is the actual URI.
+//
+// This implements the driver.Valuer interface.
+func (u *uri) Value() (driver.Value, error) {
+	return u.render(false), nil
+}
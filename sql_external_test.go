@@ -0,0 +1,56 @@
+package uri_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/fredbi/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NullURI(t *testing.T) {
+	const raw = "https://user@example.com:8080/a/b?query=value#fragment"
+
+	var (
+		_ sql.Scanner   = &uri.NullURI{}
+		_ driver.Valuer = uri.NullURI{}
+	)
+
+	for _, src := range []interface{}{raw, []byte(raw)} {
+		var u uri.NullURI
+		if assert.NoError(t, u.Scan(src)) {
+			assert.True(t, u.Valid)
+			assert.Equal(t, raw, u.URI.String())
+			assert.Equal(t, "example.com", u.URI.Authority().Host())
+
+			value, err := u.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, driver.Value(raw), value)
+		}
+	}
+
+	// a null value yields the zero URI
+	u := uri.NullURI{Valid: true}
+	if assert.NoError(t, u.Scan(nil)) {
+		assert.False(t, u.Valid)
+		if assert.NotNil(t, u.URI) {
+			assert.Equal(t, "", u.URI.String())
+			assert.Equal(t, uri.Components{}, u.URI.Components())
+		}
+
+		value, err := u.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	}
+
+	// invalid values
+	assert.NoError(t, u.Scan(raw))
+	err := u.Scan("1http://example.com")
+	assert.True(t, errors.Is(err, uri.ErrInvalidScheme))
+	err = u.Scan(123)
+	assert.True(t, errors.Is(err, uri.ErrInvalidURI))
+	assert.True(t, u.Valid, "a failed scan should not alter the NullURI")
+	assert.Equal(t, raw, u.URI.String())
+}
@@ -0,0 +1,399 @@
+package uri
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidTemplate indicates a malformed URI template
+var ErrInvalidTemplate = errors.New("invalid URI template")
+
+// templateOperator describes how to expand an expression, as per RFC 6570 Appendix A
+type templateOperator struct {
+	first         string
+	sep           string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var templateOperators = map[byte]templateOperator{
+	0:   {first: "", sep: ","},
+	'+': {first: "", sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+type templateVarSpec struct {
+	name    string
+	explode bool
+	prefix  int
+}
+
+// ExpandTemplate expands a URI template as specified by RFC 6570, then
+// parses the result as a URI.
+//
+// Variables may be strings, lists ([]string), associative arrays (map[string]string)
+// or any other value, which is then formatted as a string. Nil values and empty
+// lists are considered undefined.
+//
+// Associative arrays are expanded with their keys sorted.
+//
+// Reference: https://tools.ietf.org/html/rfc6570
+func ExpandTemplate(tmpl string, vars map[string]interface{}, opts ...Option) (URI, error) {
+	expanded, err := expandTemplate(tmpl, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(expanded, opts...)
+}
+
+// ValidateTemplate checks the syntax of a URI template as specified by RFC 6570,
+// without expanding it: braces must be balanced and expressions must use
+// valid operators, variable names and modifiers.
+//
+// Percent-encoded braces (e.g. "%7B") are literals, not expressions.
+//
+// Notice that the URI resulting from the expansion of a valid template
+// is not necessarily a valid URI.
+func ValidateTemplate(tmpl string) error {
+	_, err := expandTemplate(tmpl, nil)
+
+	return err
+}
+
+// IsURITemplate tells if a string is a valid URI template with at least one expression.
+func IsURITemplate(tmpl string) bool {
+	return strings.IndexByte(tmpl, '{') >= 0 && ValidateTemplate(tmpl) == nil
+}
+
+func expandTemplate(tmpl string, vars map[string]interface{}) (string, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(tmpl)))
+
+	for i := 0; i < len(tmpl); i++ {
+		switch tmpl[i] {
+		case '{':
+			end := strings.IndexByte(tmpl[i+1:], '}')
+			if end < 0 {
+				return "", ErrInvalidTemplate
+			}
+
+			op, specs, err := parseTemplateExpression(tmpl[i+1 : i+1+end])
+			if err != nil {
+				return "", err
+			}
+
+			if err := expandTemplateExpression(buf, op, specs, vars); err != nil {
+				return "", err
+			}
+			i += end + 1
+		case '%':
+			if i+2 >= len(tmpl) {
+				return "", ErrInvalidTemplate
+			}
+			if _, ok := unhex(tmpl[i+1], tmpl[i+2]); !ok {
+				return "", ErrInvalidTemplate
+			}
+			buf.WriteString(tmpl[i : i+3])
+			i += 2
+		default:
+			if !isTemplateLiteral(tmpl[i]) {
+				return "", ErrInvalidTemplate
+			}
+			buf.WriteByte(tmpl[i])
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// isTemplateLiteral tells if a byte may appear outside of expressions.
+//
+// Non-ASCII bytes are accepted: the expanded result is eventually validated as a URI.
+func isTemplateLiteral(c byte) bool {
+	if c <= ' ' || c == 0x7f {
+		return false
+	}
+
+	return strings.IndexByte("\"'<>\\^`{|}", c) < 0
+}
+
+// parseTemplateExpression parses the content of an expression, e.g. "?x,y*,z:3"
+func parseTemplateExpression(expression string) (templateOperator, []templateVarSpec, error) {
+	if expression == "" {
+		return templateOperator{}, nil, ErrInvalidTemplate
+	}
+
+	op, ok := templateOperators[expression[0]]
+	if ok {
+		expression = expression[1:]
+	} else {
+		op = templateOperators[0]
+	}
+
+	varList := strings.Split(expression, ",")
+	specs := make([]templateVarSpec, 0, len(varList))
+	for _, varSpec := range varList {
+		spec, err := parseTemplateVarSpec(varSpec)
+		if err != nil {
+			return templateOperator{}, nil, err
+		}
+		specs = append(specs, spec)
+	}
+
+	return op, specs, nil
+}
+
+func parseTemplateVarSpec(varSpec string) (templateVarSpec, error) {
+	var spec templateVarSpec
+
+	switch colon := strings.IndexByte(varSpec, ':'); {
+	case strings.HasSuffix(varSpec, "*"):
+		spec.explode = true
+		varSpec = varSpec[:len(varSpec)-1]
+	case colon >= 0:
+		// prefix modifier: 1 to 4 digits, not starting with 0
+		digits := varSpec[colon+1:]
+		if len(digits) == 0 || len(digits) > 4 || digits[0] == '0' {
+			return spec, ErrInvalidTemplate
+		}
+		prefix, err := strconv.Atoi(digits)
+		if err != nil || prefix <= 0 {
+			return spec, ErrInvalidTemplate
+		}
+		spec.prefix = prefix
+		varSpec = varSpec[:colon]
+	}
+
+	if !isValidTemplateVarName(varSpec) {
+		return spec, ErrInvalidTemplate
+	}
+	spec.name = varSpec
+
+	return spec, nil
+}
+
+// isValidTemplateVarName checks a variable name:
+//
+//	varname = varchar *( ["."] varchar )
+//	varchar = ALPHA / DIGIT / "_" / pct-encoded
+func isValidTemplateVarName(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_':
+		case c == '.':
+			if name[i-1] == '.' {
+				return false
+			}
+		case c == '%':
+			if i+2 >= len(name) {
+				return false
+			}
+			if _, ok := unhex(name[i+1], name[i+2]); !ok {
+				return false
+			}
+			i += 2
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func expandTemplateExpression(buf *bytes.Buffer, op templateOperator, specs []templateVarSpec, vars map[string]interface{}) error {
+	first := true
+
+	for _, spec := range specs {
+		value, isDefined := vars[spec.name]
+		if !isDefined || value == nil {
+			continue
+		}
+
+		var (
+			list  []string
+			assoc map[string]string
+		)
+
+		switch v := value.(type) {
+		case []string:
+			if len(v) == 0 {
+				continue
+			}
+			list = v
+		case map[string]string:
+			if len(v) == 0 {
+				continue
+			}
+			assoc = v
+		case string:
+			list = []string{v}
+		default:
+			list = []string{fmt.Sprint(v)}
+		}
+
+		if first {
+			buf.WriteString(op.first)
+			first = false
+		} else {
+			buf.WriteString(op.sep)
+		}
+
+		switch value.(type) {
+		case []string:
+			if spec.prefix > 0 {
+				return ErrInvalidTemplate
+			}
+			expandTemplateList(buf, op, spec, list)
+		case map[string]string:
+			if spec.prefix > 0 {
+				return ErrInvalidTemplate
+			}
+			expandTemplateAssoc(buf, op, spec, assoc)
+		default:
+			expandTemplateNamed(buf, op, spec.name, list[0])
+			writeTemplateValue(buf, truncateRunes(list[0], spec.prefix), op.allowReserved)
+		}
+	}
+
+	return nil
+}
+
+func expandTemplateNamed(buf *bytes.Buffer, op templateOperator, name, value string) {
+	if !op.named {
+		return
+	}
+
+	buf.WriteString(name)
+	if value == "" {
+		buf.WriteString(op.ifEmpty)
+
+		return
+	}
+	buf.WriteByte('=')
+}
+
+func expandTemplateList(buf *bytes.Buffer, op templateOperator, spec templateVarSpec, list []string) {
+	if !spec.explode {
+		if op.named {
+			buf.WriteString(spec.name)
+			buf.WriteByte('=')
+		}
+		for i, item := range list {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeTemplateValue(buf, item, op.allowReserved)
+		}
+
+		return
+	}
+
+	for i, item := range list {
+		if i > 0 {
+			buf.WriteString(op.sep)
+		}
+		expandTemplateNamed(buf, op, spec.name, item)
+		writeTemplateValue(buf, item, op.allowReserved)
+	}
+}
+
+func expandTemplateAssoc(buf *bytes.Buffer, op templateOperator, spec templateVarSpec, assoc map[string]string) {
+	keys := make([]string, 0, len(assoc))
+	for key := range assoc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if !spec.explode {
+		if op.named {
+			buf.WriteString(spec.name)
+			buf.WriteByte('=')
+		}
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeTemplateValue(buf, key, op.allowReserved)
+			buf.WriteByte(',')
+			writeTemplateValue(buf, assoc[key], op.allowReserved)
+		}
+
+		return
+	}
+
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteString(op.sep)
+		}
+		writeTemplateValue(buf, key, op.allowReserved)
+		if op.named && assoc[key] == "" {
+			buf.WriteString(op.ifEmpty)
+
+			continue
+		}
+		buf.WriteByte('=')
+		writeTemplateValue(buf, assoc[key], op.allowReserved)
+	}
+}
+
+// writeTemplateValue percent-encodes a value, leaving unreserved characters unchanged.
+//
+// When allowReserved is true, reserved characters and percent-encoded triplets are
+// left unchanged too.
+func writeTemplateValue(buf *bytes.Buffer, value string, allowReserved bool) {
+	const upperhex = "0123456789ABCDEF"
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case isUnreserved(c):
+			buf.WriteByte(c)
+		case allowReserved && isReserved(c):
+			buf.WriteByte(c)
+		case allowReserved && c == '%' && i+2 < len(value):
+			if _, ok := unhex(value[i+1], value[i+2]); ok {
+				buf.WriteString(value[i : i+3])
+				i += 2
+
+				continue
+			}
+			fallthrough
+		default:
+			buf.WriteByte('%')
+			buf.WriteByte(upperhex[c>>4])
+			buf.WriteByte(upperhex[c&15])
+		}
+	}
+}
+
+// truncateRunes keeps the first n runes of a string (n = 0 means no truncation)
+func truncateRunes(value string, n int) string {
+	if n == 0 || utf8.RuneCountInString(value) <= n {
+		return value
+	}
+
+	for i := range value {
+		if n == 0 {
+			return value[:i]
+		}
+		n--
+	}
+
+	return value
+}
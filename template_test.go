@@ -0,0 +1,175 @@
+package uri
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_expandTemplate exercises examples from RFC 6570
+func Test_expandTemplate(t *testing.T) {
+	vars := map[string]interface{}{
+		"var":   "value",
+		"hello": "Hello World!",
+		"path":  "/foo/bar",
+		"list":  []string{"red", "green", "blue"},
+		"keys":  map[string]string{"semi": ";", "dot": ".", "comma": ","},
+		"empty": "",
+		"x":     1024,
+		"y":     768,
+		"undef": nil,
+	}
+
+	var tests = []struct {
+		tmpl, expanded string
+	}{
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{+hello}", "Hello%20World!"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"{#var}", "#value"},
+		{"{#hello}", "#Hello%20World!"},
+		{"X{.var}", "X.value"},
+		{"{/var}", "/value"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{;x,y}", ";x=1024;y=768"},
+		{"{;x,y,empty}", ";x=1024;y=768;empty"},
+		{"{?x,y}", "?x=1024&y=768"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{x,hello,y}", "1024,Hello%20World%21,768"},
+		{"{var:3}", "val"},
+		{"{var:30}", "value"},
+		{"{+path:6}/here", "/foo/b/here"},
+		{"{#path:6}/here", "#/foo/b/here"},
+		{"{;hello:5}", ";hello=Hello"},
+		{"{list}", "red,green,blue"},
+		{"{list*}", "red,green,blue"},
+		{"{/list*}", "/red/green/blue"},
+		{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+		{"{?list}", "?list=red,green,blue"},
+		{"{?list*}", "?list=red&list=green&list=blue"},
+		{"{keys}", "comma,%2C,dot,.,semi,%3B"},
+		{"{keys*}", "comma=%2C,dot=.,semi=%3B"},
+		{"{?keys*}", "?comma=%2C&dot=.&semi=%3B"},
+		{"{undef}", ""},
+		{"{?undef}", ""},
+		{"{?undef,x}", "?x=1024"},
+		{"no/expression", "no/expression"},
+	}
+
+	for _, test := range tests {
+		expanded, err := expandTemplate(test.tmpl, vars)
+		if assert.NoErrorf(t, err, "unexpected error for template %q", test.tmpl) {
+			assert.Equalf(t, test.expanded, expanded, "unexpected expansion for template %q", test.tmpl)
+		}
+	}
+}
+
+func Test_expandTemplateInvalid(t *testing.T) {
+	vars := map[string]interface{}{
+		"list": []string{"red", "green", "blue"},
+		"keys": map[string]string{"semi": ";"},
+	}
+
+	for _, tmpl := range []string{
+		"{",
+		"}",
+		"{}",
+		"{var",
+		"var}",
+		"{{var}}",
+		"{=var}",
+		"{!var}",
+		"{var:0}",
+		"{var:10000}",
+		"{var:x}",
+		"{a..b}",
+		"{.a.}",
+		"{a b}",
+		"{x,}",
+		"{list:3}",
+		"{keys:3}",
+	} {
+		_, err := expandTemplate(tmpl, vars)
+		assert.Equalf(t, ErrInvalidTemplate, err, "expected template %q to be invalid", tmpl)
+	}
+}
+
+func Test_ExpandTemplate(t *testing.T) {
+	u, err := ExpandTemplate("http://example.com/users/{id}{?fields}{#section}", map[string]interface{}{
+		"id":      42,
+		"fields":  []string{"name", "email"},
+		"section": "top",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "http://example.com/users/42?fields=name,email#top", u.String())
+		assert.Equal(t, "/users/42", u.Authority().Path())
+	}
+
+	// the expanded result is validated
+	_, err = ExpandTemplate("http://{host}/users", map[string]interface{}{
+		"host": "bad_host",
+	})
+	assert.True(t, errors.Is(err, ErrInvalidHost))
+
+	_, err = ExpandTemplate("http://example.com/users/{id", nil)
+	assert.Equal(t, ErrInvalidTemplate, err)
+
+	// options apply to the expanded result
+	_, err = ExpandTemplate("http://{host}?q=1", nil)
+	assert.True(t, errors.Is(err, ErrMissingHost))
+
+	_, err = ExpandTemplate("http://{host}?q=1", nil, WithRequireHostForSchemes())
+	assert.NoError(t, err)
+}
+
+func Test_ValidateTemplate(t *testing.T) {
+	for _, tmpl := range []string{
+		"http://example.com/users/{id}{?fields*}",
+		"http://example.com/{+path:6}/here{#section}",
+		"{/list*,path:4}{;x,y}{.ext}{&more}",
+		"http://example.com/%7Bid%7D/{id}",
+		"http://www.詹姆斯.org/{id}",
+		"{var.name}{var_2}{%41}",
+	} {
+		assert.NoErrorf(t, ValidateTemplate(tmpl), "expected %q to be a valid template", tmpl)
+		assert.Truef(t, IsURITemplate(tmpl), "expected %q to be a URI template", tmpl)
+	}
+
+	for _, tmpl := range []string{
+		"http://example.com/users/{id",
+		"http://example.com/users/id}",
+		"http://example.com/users/{id}}",
+		"http://example.com/users/{{id}}",
+		"http://example.com/{}",
+		"http://example.com/{|id}",
+		"http://example.com/{@id}",
+		"http://example.com/{id:}",
+		"http://example.com/{id*:3}",
+		"http://example.com/{i-d}",
+		"http://example.com/{%4}",
+		"http://example.com/%7B{id}%7",
+		"http://example.com/%ZZ/{id}",
+		"http://example.com/a b/{id}",
+		"http://example.com/<{id}>",
+	} {
+		assert.Equalf(t, ErrInvalidTemplate, ValidateTemplate(tmpl), "expected %q to be an invalid template", tmpl)
+		assert.Falsef(t, IsURITemplate(tmpl), "expected %q not to be a URI template", tmpl)
+	}
+
+	// valid templates, without any expression
+	for _, tmpl := range []string{
+		"http://example.com/users",
+		"http://example.com/%7Bid%7D",
+		"",
+	} {
+		assert.NoErrorf(t, ValidateTemplate(tmpl), "expected %q to be a valid template", tmpl)
+		assert.Falsef(t, IsURITemplate(tmpl), "expected %q not to be a URI template", tmpl)
+	}
+
+	// literal braces remain invalid in URIs
+	assert.False(t, IsURI("http://example.com/users/{id}"))
+	assert.True(t, IsURI("http://example.com/users/%7Bid%7D"))
+}